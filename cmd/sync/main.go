@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/cecobask/imdb-trakt-sync/pkg/client"
+	"github.com/cecobask/imdb-trakt-sync/pkg/progress"
+	"github.com/mattn/go-isatty"
+	"go.uber.org/zap"
+)
+
+func main() {
+	var (
+		noProgress = flag.Bool("no-progress", false, "disable the terminal progress bar")
+		silent     = flag.Bool("silent", false, "suppress all non-error output")
+	)
+	flag.Parse()
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failure creating logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var reporter progress.Progress = progress.NewNoop()
+	if !*noProgress && !*silent && isatty.IsTerminal(os.Stdout.Fd()) {
+		reporter = progress.NewTerminal()
+	}
+
+	imdbClient, err := client.NewImdbClient(ctx, client.ImdbConfig{
+		CookieAtMain:   os.Getenv("IMDB_COOKIE_AT_MAIN"),
+		CookieUbidMain: os.Getenv("IMDB_COOKIE_UBID_MAIN"),
+		UserId:         os.Getenv("IMDB_USER_ID"),
+		Progress:       reporter,
+	}, logger)
+	if err != nil {
+		logger.Fatal("failure creating imdb client", zap.Error(err))
+	}
+
+	if _, err := imdbClient.ListsScrape(ctx); err != nil {
+		if ctx.Err() != nil {
+			logger.Warn("sync aborted by signal", zap.Error(ctx.Err()))
+			os.Exit(1)
+		}
+		logger.Fatal("failure syncing imdb lists", zap.Error(err))
+	}
+}
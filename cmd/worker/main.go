@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/cecobask/imdb-trakt-sync/pkg/client"
+	"github.com/cecobask/imdb-trakt-sync/pkg/worker"
+	"go.uber.org/zap"
+)
+
+func main() {
+	var (
+		queuePath   = flag.String("queue-path", "worker.db", "path to the bolt db backing the job queue")
+		concurrency = flag.Int("concurrency", 2, "number of worker goroutines processing jobs concurrently")
+		adminAddr   = flag.String("admin-addr", ":8090", "address the admin http endpoint listens on")
+	)
+	flag.Parse()
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failure creating logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+
+	queue, err := worker.NewBoltQueue(*queuePath)
+	if err != nil {
+		logger.Fatal("failure opening job queue", zap.Error(err))
+	}
+	defer queue.Close()
+
+	imdbClient, err := client.NewImdbClient(context.Background(), client.ImdbConfig{
+		CookieAtMain:   os.Getenv("IMDB_COOKIE_AT_MAIN"),
+		CookieUbidMain: os.Getenv("IMDB_COOKIE_UBID_MAIN"),
+		UserId:         os.Getenv("IMDB_USER_ID"),
+	}, logger)
+	if err != nil {
+		logger.Fatal("failure creating imdb client", zap.Error(err))
+	}
+
+	pool := worker.NewPool(queue, newRunner(imdbClient), *concurrency, logger)
+	pool.Start()
+	defer pool.Stop()
+
+	admin := worker.NewAdminServer(queue, logger)
+	logger.Info("admin endpoint listening", zap.String("addr", *adminAddr))
+	if err := http.ListenAndServe(*adminAddr, admin.Handler()); err != nil {
+		logger.Fatal("admin endpoint failed", zap.Error(err))
+	}
+}
+
+// newRunner adapts the imdb client into a worker.Runner. A 403 ImdbError
+// means the configured cookies expired - no amount of retrying fixes that,
+// so it's translated into a worker.TerminalError. Any other ImdbError (a
+// transient 5xx, a rate limit, ...) is left as a plain error so the pool
+// keeps retrying it with backoff.
+func newRunner(imdbClient client.ImdbClientInterface) worker.Runner {
+	return func(ctx context.Context, job *worker.Job) error {
+		var err error
+		switch job.Kind {
+		case worker.KindRatingsOnly:
+			_, err = imdbClient.RatingsGet(ctx)
+		case worker.KindWatchlistOnly:
+			_, _, err = imdbClient.WatchlistGet(ctx)
+		case worker.KindSingleList:
+			_, _, err = imdbClient.ListItemsGet(ctx, job.ListId)
+		case worker.KindFullSync:
+			_, err = imdbClient.ListsScrape(ctx)
+		default:
+			return fmt.Errorf("unknown job kind %q", job.Kind)
+		}
+		if err == nil {
+			return nil
+		}
+		var imdbErr *client.ImdbError
+		if errors.As(err, &imdbErr) && imdbErr.StatusCode() == http.StatusForbidden {
+			return &worker.TerminalError{Err: err}
+		}
+		return err
+	}
+}
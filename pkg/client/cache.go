@@ -0,0 +1,144 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	cacheKeyList      = "imdb.list.%s"
+	cacheKeyRatings   = "imdb.ratings.%s"
+	cacheKeyWatchlist = "imdb.watchlist.%s"
+
+	defaultCacheTTL = time.Hour
+)
+
+// cacheEntry is what gets persisted for a single cached response: the raw
+// CSV body, so it can be re-parsed without another network round trip, the
+// response headers (readResponse needs Content-Disposition, conditionalGet
+// the ETag), and a hash used to detect
+// whether a freshly fetched body actually changed since the last sync.
+type cacheEntry struct {
+	Body      []byte
+	Header    http.Header
+	Hash      string
+	ExpiresAt time.Time
+}
+
+func (e *cacheEntry) expired() bool {
+	return time.Now().After(e.ExpiresAt)
+}
+
+// Cache is a pluggable store for previously fetched imdb CSV exports, keyed
+// by resource - imdb.list.<id>, imdb.ratings.<userId>, imdb.watchlist.<id>.
+type Cache interface {
+	Get(key string) (*cacheEntry, bool)
+	Set(key string, entry *cacheEntry) error
+}
+
+// memoryCache is a process-local Cache with no persistence across restarts.
+type memoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]*cacheEntry
+}
+
+func NewMemoryCache() Cache {
+	return &memoryCache{
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+func (c *memoryCache) Get(key string) (*cacheEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+func (c *memoryCache) Set(key string, entry *cacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry
+	return nil
+}
+
+// fileCache persists one gob-encoded file per key under dir, so cached
+// responses survive a process restart between scheduled syncs.
+type fileCache struct {
+	dir string
+}
+
+// NewFileCache returns a Cache backed by dir, creating it if necessary. An
+// empty dir defaults to $XDG_CACHE_HOME/imdb-trakt-sync via os.UserCacheDir.
+func NewFileCache(dir string) (Cache, error) {
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("failure resolving default cache directory: %w", err)
+		}
+		dir = filepath.Join(base, "imdb-trakt-sync")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failure creating cache directory %s: %w", dir, err)
+	}
+	return &fileCache{dir: dir}, nil
+}
+
+func (c *fileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".gob")
+}
+
+func (c *fileCache) Get(key string) (*cacheEntry, bool) {
+	file, err := os.Open(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	defer file.Close()
+	var entry cacheEntry
+	if err := gob.NewDecoder(file).Decode(&entry); err != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func (c *fileCache) Set(key string, entry *cacheEntry) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(entry); err != nil {
+		return fmt.Errorf("failure encoding cache entry for %s: %w", key, err)
+	}
+	if err := os.WriteFile(c.path(key), buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failure writing cache entry for %s: %w", key, err)
+	}
+	return nil
+}
+
+// setupCache builds the Cache described by dir/ttl. Caching is only enabled
+// when the caller opted in via dir or ttl - most callers (e.g. a one-shot
+// CLI invocation) have nothing to gain from it.
+func setupCache(dir string, ttl time.Duration) (Cache, time.Duration, error) {
+	if dir == "" && ttl == 0 {
+		return nil, 0, nil
+	}
+	cache, err := NewFileCache(dir)
+	if err != nil {
+		return nil, 0, err
+	}
+	if ttl == 0 {
+		ttl = defaultCacheTTL
+	}
+	return cache, ttl, nil
+}
+
+func hashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// deadlineTimer races a blocking operation against ctx's deadline. Call
+// finish from the goroutine performing the operation once it completes;
+// wait blocks until that happens or ctx is done, whichever comes first,
+// returning a wrapped context.DeadlineExceeded/context.Canceled in the
+// latter case so a hung imdb response can't block a caller forever.
+type deadlineTimer struct {
+	ctx  context.Context
+	done chan struct{}
+}
+
+func newDeadlineTimer(ctx context.Context) *deadlineTimer {
+	return &deadlineTimer{
+		ctx:  ctx,
+		done: make(chan struct{}),
+	}
+}
+
+func (dt *deadlineTimer) finish() {
+	close(dt.done)
+}
+
+func (dt *deadlineTimer) wait() error {
+	select {
+	case <-dt.done:
+		return nil
+	case <-dt.ctx.Done():
+		return fmt.Errorf("imdb request cancelled while reading response: %w", dt.ctx.Err())
+	}
+}
@@ -0,0 +1,66 @@
+package client
+
+import "fmt"
+
+// ImdbError wraps a non-OK response from an imdb request. StatusCode lets
+// callers (e.g. the worker runner) tell an expired-cookie 403 - which a
+// re-run can't fix - apart from a transient 5xx worth retrying.
+type ImdbError struct {
+	httpMethod string
+	url        string
+	statusCode int
+	details    string
+}
+
+func (e *ImdbError) Error() string {
+	return fmt.Sprintf("imdb request %s %s failed: %s", e.httpMethod, e.url, e.details)
+}
+
+// StatusCode returns the http status code imdb responded with.
+func (e *ImdbError) StatusCode() int {
+	return e.statusCode
+}
+
+// CsvParseError wraps a failure to parse an imdb CSV export as a CSV
+// document at all, or a list row with fewer fields than imdb's documented
+// export layout (e.g. after a redesign), as opposed to a failure to
+// interpret an otherwise well-formed row.
+type CsvParseError struct {
+	Err error
+}
+
+func (e *CsvParseError) Error() string {
+	return fmt.Sprintf("failure parsing imdb response as csv: %v", e.Err)
+}
+
+func (e *CsvParseError) Unwrap() error {
+	return e.Err
+}
+
+// HeaderMissingError indicates an expected response header wasn't present,
+// e.g. Content-Disposition on a list export that IMDB normally sends the
+// list name in.
+type HeaderMissingError struct {
+	Header string
+}
+
+func (e *HeaderMissingError) Error() string {
+	return fmt.Sprintf("missing expected header %s in imdb response", e.Header)
+}
+
+// RatingParseError wraps a failure to interpret a single row of an imdb
+// ratings export, identifying the row so the caller can report which
+// rating failed. Parsing stops at the first such row and no ratings are
+// returned.
+type RatingParseError struct {
+	Row int
+	Err error
+}
+
+func (e *RatingParseError) Error() string {
+	return fmt.Sprintf("failure parsing imdb rating at row %d: %v", e.Row, e.Err)
+}
+
+func (e *RatingParseError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// ListFilter controls which imdb lists ListsScrape processes. A list is
+// included when it matches at least one Include rule (or no Include rules
+// are configured) and no Exclude rule - so users with dozens of imdb lists
+// can skip private/experimental ones without an extra network call per list.
+type ListFilter struct {
+	Include []ListRule
+	Exclude []ListRule
+}
+
+// ListRule matches a list by id, by a glob against its name, or by a tag
+// assigned to it via ImdbConfig.TagStorePath. Exactly one field should be set.
+type ListRule struct {
+	ListId   string
+	NameGlob string
+	Tag      string
+}
+
+func (f ListFilter) matches(listId, listName string, tags []string) bool {
+	if len(f.Include) > 0 && !anyRuleMatches(f.Include, listId, listName, tags) {
+		return false
+	}
+	return !anyRuleMatches(f.Exclude, listId, listName, tags)
+}
+
+func anyRuleMatches(rules []ListRule, listId, listName string, tags []string) bool {
+	for _, rule := range rules {
+		switch {
+		case rule.ListId != "":
+			if rule.ListId == listId {
+				return true
+			}
+		case rule.NameGlob != "":
+			if ok, _ := filepath.Match(rule.NameGlob, listName); ok {
+				return true
+			}
+		case rule.Tag != "":
+			for _, tag := range tags {
+				if tag == rule.Tag {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// SortOrder controls the order ListItemsGetByTag concatenates items from
+// the lists carrying the requested tag, similar to a podcast library's
+// episode ordering.
+type SortOrder string
+
+const (
+	SortByDateAdded    SortOrder = "date-added"
+	SortByName         SortOrder = "name"
+	SortByLastModified SortOrder = "last-modified"
+)
+
+// listMeta is everything ListsScrape can learn about an imdb list from the
+// listing page alone, before spending a request fetching its CSV export.
+type listMeta struct {
+	id           string
+	name         string
+	dateAdded    string
+	lastModified string
+}
+
+func sortListMetaBy(lists []listMeta, order SortOrder) {
+	sort.SliceStable(lists, func(i, j int) bool {
+		switch order {
+		case SortByName:
+			return lists[i].name < lists[j].name
+		case SortByLastModified:
+			return lists[i].lastModified < lists[j].lastModified
+		default:
+			return lists[i].dateAdded < lists[j].dateAdded
+		}
+	})
+}
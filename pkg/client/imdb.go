@@ -2,15 +2,16 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/cecobask/imdb-trakt-sync/pkg/entities"
+	"github.com/cecobask/imdb-trakt-sync/pkg/progress"
 	"go.uber.org/zap"
 	"io"
-	"log"
 	"mime"
 	"net/http"
 	"net/http/cookiejar"
@@ -25,6 +26,8 @@ const (
 	imdbCookieNameAtMain            = "at-main"
 	imdbCookieNameUbidMain          = "ubid-main"
 	imdbHeaderKeyContentDisposition = "Content-Disposition"
+	imdbHeaderKeyETag               = "ETag"
+	imdbHeaderKeyIfNoneMatch        = "If-None-Match"
 	imdbPathBase                    = "https://www.imdb.com"
 	imdbPathListExport              = "/list/%s/export"
 	imdbPathLists                   = "/user/%s/lists"
@@ -38,6 +41,12 @@ type ImdbClient struct {
 	client   *http.Client
 	config   ImdbConfig
 	logger   *zap.Logger
+	cache    Cache
+	cacheTTL time.Duration
+	omdb     *OmdbClient
+	progress progress.Progress
+	filter   ListFilter
+	tags     *TagStore
 }
 
 type ImdbConfig struct {
@@ -45,22 +54,69 @@ type ImdbConfig struct {
 	CookieUbidMain string
 	UserId         string
 	WatchlistId    string
+	// CacheDir enables on-disk response caching when non-empty, or when
+	// CacheTTL is set without CacheDir (in which case the OS default cache
+	// directory is used). Leave both zero to disable caching entirely.
+	CacheDir string
+	// CacheTTL overrides how long a cached response is considered fresh.
+	// Defaults to defaultCacheTTL when CacheDir is set but CacheTTL isn't.
+	CacheTTL time.Duration
+	// OmdbApiKey enables OMDb enrichment of items whose TitleType couldn't
+	// be read from the imdb CSV export. Leave empty to disable enrichment.
+	OmdbApiKey string
+	// Progress receives Start/SetTotal/Increment/Finish calls as ListsScrape
+	// and RatingsGet iterate items. Defaults to progress.NewNoop() when nil.
+	Progress progress.Progress
+	// Filter restricts which imdb lists ListsScrape processes. The zero
+	// value matches every list.
+	Filter ListFilter
+	// TagStorePath enables per-list tags (see TagStore) and ListItemsGetByTag
+	// when non-empty. Leave empty to disable tag support.
+	TagStorePath string
 }
 
-func NewImdbClient(config ImdbConfig, logger *zap.Logger) (ImdbClientInterface, error) {
+func NewImdbClient(ctx context.Context, config ImdbConfig, logger *zap.Logger) (ImdbClientInterface, error) {
 	jar, err := setupCookieJar(config)
 	if err != nil {
 		return nil, err
 	}
+	cache, cacheTTL, err := setupCache(config.CacheDir, config.CacheTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failure setting up imdb response cache: %w", err)
+	}
+	reporter := config.Progress
+	if reporter == nil {
+		reporter = progress.NewNoop()
+	}
 	client := &ImdbClient{
 		endpoint: imdbPathBase,
 		client: &http.Client{
 			Jar: jar,
 		},
-		config: config,
-		logger: logger,
+		config:   config,
+		logger:   logger,
+		cache:    cache,
+		cacheTTL: cacheTTL,
+		progress: reporter,
+		filter:   config.Filter,
+	}
+	if config.OmdbApiKey != "" {
+		client.omdb, err = NewOmdbClient(OmdbConfig{
+			ApiKey:   config.OmdbApiKey,
+			CacheDir: config.CacheDir,
+			CacheTTL: config.CacheTTL,
+		}, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failure setting up omdb enrichment client: %w", err)
+		}
+	}
+	if config.TagStorePath != "" {
+		client.tags, err = NewTagStore(config.TagStorePath)
+		if err != nil {
+			return nil, fmt.Errorf("failure setting up imdb list tag store: %w", err)
+		}
 	}
-	if err = client.Hydrate(); err != nil {
+	if err = client.Hydrate(ctx); err != nil {
 		return nil, fmt.Errorf("failure hydrating imdb client: %w", err)
 	}
 	return client, nil
@@ -88,23 +144,26 @@ func setupCookieJar(config ImdbConfig) (http.CookieJar, error) {
 	return jar, nil
 }
 
-func (c *ImdbClient) Hydrate() error {
+func (c *ImdbClient) Hydrate(ctx context.Context) error {
 	if c.config.UserId == "" || c.config.UserId == "scrape" {
-		if err := c.UserIdScrape(); err != nil {
+		if err := c.UserIdScrape(ctx); err != nil {
 			return fmt.Errorf("failure scraping imdb user id: %w", err)
 		}
 	}
-	if err := c.WatchlistIdScrape(); err != nil {
+	if err := c.WatchlistIdScrape(ctx); err != nil {
 		return fmt.Errorf("failure scraping imdb watchlist id: %w", err)
 	}
 	return nil
 }
 
-func (c *ImdbClient) doRequest(params requestParams) (*http.Response, error) {
-	req, err := http.NewRequest(params.Method, c.endpoint+params.Path, nil)
+func (c *ImdbClient) doRequest(ctx context.Context, params requestParams) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, params.Method, c.endpoint+params.Path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failure creating http request %s %s: %w", params.Method, c.endpoint+params.Path, err)
 	}
+	for key, value := range params.Headers {
+		req.Header.Set(key, value)
+	}
 	if params.Body != nil {
 		body, err := json.Marshal(params.Body)
 		if err != nil {
@@ -114,11 +173,15 @@ func (c *ImdbClient) doRequest(params requestParams) (*http.Response, error) {
 	}
 	res, err := c.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failure sending http request %s %s: %w", params.Method, res.Request.URL, err)
+		// a cancelled or expired ctx surfaces here wrapped in a *url.Error;
+		// propagate it as-is so callers can distinguish it with errors.Is.
+		return nil, fmt.Errorf("failure sending http request %s %s: %w", params.Method, c.endpoint+params.Path, err)
 	}
 	switch res.StatusCode {
 	case http.StatusOK:
 		break
+	case http.StatusNotModified:
+		break // caller revalidates the If-None-Match hit against its own cache
 	case http.StatusForbidden:
 		return nil, &ImdbError{
 			httpMethod: req.Method,
@@ -139,88 +202,197 @@ func (c *ImdbClient) doRequest(params requestParams) (*http.Response, error) {
 	return res, nil
 }
 
-func (c *ImdbClient) ListItemsGet(listId string) (*string, []entities.ImdbItem, error) {
+func (c *ImdbClient) ListItemsGet(ctx context.Context, listId string) (*string, []entities.ImdbItem, error) {
+	cacheKey := fmt.Sprintf(cacheKeyList, listId)
+	if listName, list, ok := c.readCache(ctx, cacheKey, resourceTypeList); ok {
+		return listName, list, nil
+	}
 	path := fmt.Sprintf(imdbPathListExport, listId)
-	res, err := c.doRequest(requestParams{
-		Method: http.MethodGet,
-		Path:   path,
-	})
+	res, err := c.doRequest(ctx, c.conditionalGet(path, cacheKey))
 	if err != nil {
 		return nil, nil, fmt.Errorf("failure trying to retrieve imdb list %s: %w", listId, err)
 	}
-	defer DrainBody(res.Body)
+	defer drainBody(c.logger, res.Body)
 	if res.StatusCode == http.StatusNotFound {
 		return nil, nil, &ResourceNotFoundError{
 			resourceType: resourceTypeList,
 			resourceId:   &listId,
 		}
 	}
-	listName, list := readResponse(res, resourceTypeList)
+	if res.StatusCode == http.StatusNotModified {
+		listName, list, err := c.reviveCache(ctx, cacheKey, resourceTypeList)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failure reviving cached imdb list %s: %w", listId, err)
+		}
+		return listName, list, nil
+	}
+	body, err := readBody(ctx, res.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failure reading imdb list %s response body: %w", listId, err)
+	}
+	if c.writeCache(cacheKey, body, res.Header) {
+		c.logger.Debug("imdb list content unchanged despite a live request", zap.String("listId", listId))
+	}
+	listName, list, err := c.readResponse(ctx, body, res.Header, resourceTypeList)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failure reading imdb list %s response: %w", listId, err)
+	}
 	return listName, list, nil
 }
 
-func (c *ImdbClient) WatchlistGet() (*string, []entities.ImdbItem, error) {
+func (c *ImdbClient) WatchlistGet(ctx context.Context) (*string, []entities.ImdbItem, error) {
+	cacheKey := fmt.Sprintf(cacheKeyWatchlist, c.config.WatchlistId)
+	if _, list, ok := c.readCache(ctx, cacheKey, resourceTypeList); ok {
+		return &c.config.WatchlistId, list, nil
+	}
 	path := fmt.Sprintf(imdbPathListExport, c.config.WatchlistId)
-	res, err := c.doRequest(requestParams{
-		Method: http.MethodGet,
-		Path:   path,
-	})
+	res, err := c.doRequest(ctx, c.conditionalGet(path, cacheKey))
 	if err != nil {
 		return nil, nil, fmt.Errorf("failure trying to retrieve imdb watchlist %s: %w", c.config.WatchlistId, err)
 	}
-	defer DrainBody(res.Body)
+	defer drainBody(c.logger, res.Body)
 	if res.StatusCode == http.StatusNotFound {
 		return nil, nil, &ResourceNotFoundError{
 			resourceType: resourceTypeWatchlist,
 			resourceId:   &c.config.WatchlistId,
 		}
 	}
-	_, list := readResponse(res, resourceTypeList)
+	if res.StatusCode == http.StatusNotModified {
+		_, list, err := c.reviveCache(ctx, cacheKey, resourceTypeList)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failure reviving cached imdb watchlist %s: %w", c.config.WatchlistId, err)
+		}
+		return &c.config.WatchlistId, list, nil
+	}
+	body, err := readBody(ctx, res.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failure reading imdb watchlist response body: %w", err)
+	}
+	if c.writeCache(cacheKey, body, res.Header) {
+		c.logger.Debug("imdb watchlist content unchanged despite a live request", zap.String("watchlistId", c.config.WatchlistId))
+	}
+	_, list, err := c.readResponse(ctx, body, res.Header, resourceTypeList)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failure reading imdb watchlist response: %w", err)
+	}
 	return &c.config.WatchlistId, list, nil
 }
 
-func (c *ImdbClient) ListsScrape() (dps []entities.DataPair, err error) {
-	res, err := c.doRequest(requestParams{
+func (c *ImdbClient) ListsScrape(ctx context.Context) (dps []entities.DataPair, err error) {
+	lists, err := c.discoverLists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.progress.Start("imdb lists")
+	c.progress.SetTotal(len(lists))
+	for _, lm := range lists {
+		if ctx.Err() != nil {
+			break
+		}
+		if !c.filter.matches(lm.id, lm.name, c.listTags(lm.id)) {
+			c.progress.Increment()
+			continue
+		}
+		imdbListName, imdbList, listErr := c.ListItemsGet(ctx, lm.id)
+		c.progress.Increment()
+		if errors.As(listErr, new(*ResourceNotFoundError)) {
+			continue
+		}
+		if listErr != nil {
+			c.logger.Error("failure scraping imdb list", zap.String("listId", lm.id), zap.Error(listErr))
+			continue
+		}
+		dps = append(dps, entities.DataPair{
+			ImdbList:     imdbList,
+			ImdbListId:   lm.id,
+			ImdbListName: *imdbListName,
+			TraktListId:  FormatTraktListName(*imdbListName),
+		})
+	}
+	c.progress.Finish()
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("failure scraping imdb lists: %w", ctx.Err())
+	}
+	return dps, nil
+}
+
+// discoverLists lists the ids and names of every imdb list the configured
+// user has, without fetching each list's CSV export.
+func (c *ImdbClient) discoverLists(ctx context.Context) ([]listMeta, error) {
+	res, err := c.doRequest(ctx, requestParams{
 		Method: http.MethodGet,
 		Path:   fmt.Sprintf(imdbPathLists, c.config.UserId),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failure trying to scrape imdb lists: %w", err)
 	}
-	defer DrainBody(res.Body)
+	defer drainBody(c.logger, res.Body)
 	doc, err := goquery.NewDocumentFromReader(res.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failure creating goquery document from imdb response: %w", err)
 	}
+	var lists []listMeta
 	doc.Find(".user-list").Each(func(i int, selection *goquery.Selection) {
 		imdbListId, ok := selection.Attr("id")
 		if !ok {
 			c.logger.Info("found no imdb lists")
 			return
 		}
-		imdbListName, imdbList, err := c.ListItemsGet(imdbListId)
-		if errors.As(err, new(*ResourceNotFoundError)) {
-			return
-		}
-		dps = append(dps, entities.DataPair{
-			ImdbList:     imdbList,
-			ImdbListId:   imdbListId,
-			ImdbListName: *imdbListName,
-			TraktListId:  FormatTraktListName(*imdbListName),
+		lists = append(lists, listMeta{
+			id:           imdbListId,
+			name:         strings.TrimSpace(selection.Find(".list-name").Text()),
+			dateAdded:    selection.AttrOr("data-list-date-created", ""),
+			lastModified: selection.AttrOr("data-list-date-modified", ""),
 		})
 	})
-	return dps, nil
+	return lists, nil
+}
+
+// listTags returns the tags assigned to listId, or nil when no tag store is
+// configured.
+func (c *ImdbClient) listTags(listId string) []string {
+	if c.tags == nil {
+		return nil
+	}
+	return c.tags.Tags(listId)
+}
+
+// ListItemsGetByTag returns every item across the imdb lists tagged with
+// tag, sorted by sortOrder. Unlike ListsScrape it ignores the client's
+// configured ListFilter, since a caller asking for a tag wants every list
+// carrying it regardless of the default include/exclude rules.
+func (c *ImdbClient) ListItemsGetByTag(ctx context.Context, tag string, sortOrder SortOrder) ([]entities.ImdbItem, error) {
+	if c.tags == nil {
+		return nil, fmt.Errorf("no imdb list tag store configured")
+	}
+	lists, err := c.discoverLists(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failure discovering imdb lists tagged %s: %w", tag, err)
+	}
+	sortListMetaBy(lists, sortOrder)
+	var items []entities.ImdbItem
+	for _, lm := range lists {
+		if !c.tags.HasTag(lm.id, tag) {
+			continue
+		}
+		_, list, err := c.ListItemsGet(ctx, lm.id)
+		if err != nil {
+			return nil, fmt.Errorf("failure retrieving imdb list %s tagged %s: %w", lm.id, tag, err)
+		}
+		items = append(items, list...)
+	}
+	return items, nil
 }
 
-func (c *ImdbClient) UserIdScrape() error {
-	res, err := c.doRequest(requestParams{
+func (c *ImdbClient) UserIdScrape(ctx context.Context) error {
+	res, err := c.doRequest(ctx, requestParams{
 		Method: http.MethodGet,
 		Path:   imdbPathProfile,
 	})
 	if err != nil {
 		return fmt.Errorf("failure trying to scrape imdb user id: %w", err)
 	}
-	defer DrainBody(res.Body)
+	defer drainBody(c.logger, res.Body)
 	doc, err := goquery.NewDocumentFromReader(res.Body)
 	if err != nil {
 		return fmt.Errorf("failure creating goquery document from imdb response: %w", err)
@@ -233,15 +405,15 @@ func (c *ImdbClient) UserIdScrape() error {
 	return nil
 }
 
-func (c *ImdbClient) WatchlistIdScrape() error {
-	res, err := c.doRequest(requestParams{
+func (c *ImdbClient) WatchlistIdScrape(ctx context.Context) error {
+	res, err := c.doRequest(ctx, requestParams{
 		Method: http.MethodGet,
 		Path:   imdbPathWatchlist,
 	})
 	if err != nil {
 		return fmt.Errorf("failure trying to scrape imdb watchlist id: %w", err)
 	}
-	defer DrainBody(res.Body)
+	defer drainBody(c.logger, res.Body)
 	doc, err := goquery.NewDocumentFromReader(res.Body)
 	if err != nil {
 		return fmt.Errorf("failure creating goquery document from imdb response: %w", err)
@@ -254,61 +426,181 @@ func (c *ImdbClient) WatchlistIdScrape() error {
 	return nil
 }
 
-func (c *ImdbClient) RatingsGet() ([]entities.ImdbItem, error) {
-	res, err := c.doRequest(requestParams{
-		Method: http.MethodGet,
-		Path:   fmt.Sprintf(imdbPathRatingsExport, c.config.UserId),
-	})
+func (c *ImdbClient) RatingsGet(ctx context.Context) ([]entities.ImdbItem, error) {
+	cacheKey := fmt.Sprintf(cacheKeyRatings, c.config.UserId)
+	if _, ratings, ok := c.readCache(ctx, cacheKey, resourceTypeRating); ok {
+		return ratings, nil
+	}
+	path := fmt.Sprintf(imdbPathRatingsExport, c.config.UserId)
+	res, err := c.doRequest(ctx, c.conditionalGet(path, cacheKey))
 	if err != nil {
 		return nil, fmt.Errorf("failure trying to retrieve imdb ratings: %w", err)
 	}
-	defer DrainBody(res.Body)
+	defer drainBody(c.logger, res.Body)
 	if res.StatusCode == http.StatusNotFound {
 		return nil, &ResourceNotFoundError{
 			resourceType: resourceTypeRating,
 		}
 	}
-	_, ratings := readResponse(res, resourceTypeRating)
+	if res.StatusCode == http.StatusNotModified {
+		_, ratings, err := c.reviveCache(ctx, cacheKey, resourceTypeRating)
+		if err != nil {
+			return nil, fmt.Errorf("failure reviving cached imdb ratings: %w", err)
+		}
+		return ratings, nil
+	}
+	body, err := readBody(ctx, res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failure reading imdb ratings response body: %w", err)
+	}
+	if c.writeCache(cacheKey, body, res.Header) {
+		c.logger.Debug("imdb ratings content unchanged despite a live request", zap.String("userId", c.config.UserId))
+	}
+	_, ratings, err := c.readResponse(ctx, body, res.Header, resourceTypeRating)
+	if err != nil {
+		return nil, fmt.Errorf("failure reading imdb ratings response: %w", err)
+	}
 	return ratings, nil
 }
 
-func readResponse(res *http.Response, resType string) (imdbListName *string, imdbList []entities.ImdbItem) {
-	csvReader := csv.NewReader(res.Body)
+// readCache returns the cached response for key if caching is enabled and a
+// fresh entry exists. ok is false on any cache miss or parse failure, so
+// callers always fall through to a live request.
+func (c *ImdbClient) readCache(ctx context.Context, key, resType string) (*string, []entities.ImdbItem, bool) {
+	if c.cache == nil {
+		return nil, nil, false
+	}
+	entry, ok := c.cache.Get(key)
+	if !ok || entry.expired() {
+		return nil, nil, false
+	}
+	name, list, err := c.readResponse(ctx, entry.Body, entry.Header, resType)
+	if err != nil {
+		c.logger.Warn("failure reading cached imdb response, falling back to a live request", zap.String("key", key), zap.Error(err))
+		return nil, nil, false
+	}
+	return name, list, true
+}
+
+// conditionalGet builds the requestParams for a GET against path, attaching
+// an If-None-Match header carrying the ETag imdb sent with whatever response
+// is cached for key (even an expired one), so imdb can reply 304 instead of
+// resending a body that hasn't changed. Nothing is sent when the cached
+// response never had an ETag - imdb won't recognise our own content hash as
+// one.
+func (c *ImdbClient) conditionalGet(path, key string) requestParams {
+	params := requestParams{
+		Method: http.MethodGet,
+		Path:   path,
+	}
+	if c.cache == nil {
+		return params
+	}
+	entry, ok := c.cache.Get(key)
+	if !ok {
+		return params
+	}
+	if etag := entry.Header.Get(imdbHeaderKeyETag); etag != "" {
+		params.Headers = map[string]string{imdbHeaderKeyIfNoneMatch: etag}
+	}
+	return params
+}
+
+// reviveCache re-reads the response cached under key after imdb confirmed
+// via 304 Not Modified that it's still current, refreshing the TTL so the
+// next sync doesn't revalidate again until it expires.
+func (c *ImdbClient) reviveCache(ctx context.Context, key, resType string) (*string, []entities.ImdbItem, error) {
+	entry, ok := c.cache.Get(key)
+	if !ok {
+		return nil, nil, fmt.Errorf("imdb reported %s unchanged but no cache entry exists for it", key)
+	}
+	c.writeCache(key, entry.Body, entry.Header)
+	return c.readResponse(ctx, entry.Body, entry.Header, resType)
+}
+
+// writeCache persists body under key when caching is enabled, and reports
+// whether body hashes the same as whatever was cached for key before. imdb
+// doesn't always have (or honour) an ETag to revalidate against, so a full
+// refetch can still turn out to carry identical content - unchanged lets a
+// caller skip diffing that content against trakt instead of assuming it's
+// new just because it came from a live request rather than the cache.
+func (c *ImdbClient) writeCache(key string, body []byte, header http.Header) (unchanged bool) {
+	if c.cache == nil {
+		return false
+	}
+	hash := hashBody(body)
+	if prev, ok := c.cache.Get(key); ok {
+		unchanged = prev.Hash == hash
+	}
+	entry := &cacheEntry{
+		Body:      body,
+		Header:    header,
+		Hash:      hash,
+		ExpiresAt: time.Now().Add(c.cacheTTL),
+	}
+	if err := c.cache.Set(key, entry); err != nil {
+		c.logger.Warn("failure writing imdb response cache entry", zap.String("key", key), zap.Error(err))
+	}
+	return unchanged
+}
+
+func (c *ImdbClient) readResponse(ctx context.Context, body []byte, header http.Header, resType string) (imdbListName *string, imdbList []entities.ImdbItem, err error) {
+	csvReader := csv.NewReader(bytes.NewReader(body))
 	csvReader.LazyQuotes = true
 	csvReader.FieldsPerRecord = -1
 	csvData, err := csvReader.ReadAll()
 	if err != nil {
-		log.Fatalf("error reading imdb response: %v", err)
+		return nil, nil, &CsvParseError{Err: err}
 	}
 	switch resType {
 	case resourceTypeList:
+		const listFieldCount = 8 // indices 1 (const) and 7 (title type) are the furthest read
 		for i, record := range csvData {
 			if i > 0 { // omit header line
-				imdbList = append(imdbList, entities.ImdbItem{
+				if len(record) < listFieldCount {
+					return nil, nil, &CsvParseError{Err: fmt.Errorf("row %d has %d fields, expected at least %d", i, len(record), listFieldCount)}
+				}
+				item := entities.ImdbItem{
 					Id:        record[1],
 					TitleType: record[7],
-				})
+				}
+				if item.TitleType == "" && c.omdb != nil {
+					item = c.enrichItem(ctx, item)
+				}
+				imdbList = append(imdbList, item)
 			}
 		}
-		contentDispositionHeader := res.Header.Get(imdbHeaderKeyContentDisposition)
+		contentDispositionHeader := header.Get(imdbHeaderKeyContentDisposition)
 		if contentDispositionHeader == "" {
-			log.Fatalf("error reading header %s from imdb response", imdbHeaderKeyContentDisposition)
+			return nil, nil, &HeaderMissingError{Header: imdbHeaderKeyContentDisposition}
 		}
-		_, params, err := mime.ParseMediaType(contentDispositionHeader)
-		if err != nil || len(params) == 0 {
-			log.Fatalf("error parsing media type from header: %v", err)
+		_, params, perr := mime.ParseMediaType(contentDispositionHeader)
+		if perr != nil || len(params) == 0 {
+			return nil, nil, fmt.Errorf("failure parsing media type from header %s: %w", imdbHeaderKeyContentDisposition, perr)
 		}
-		imdbListName = &strings.Split(params["filename"], ".")[0]
+		name := strings.Split(params["filename"], ".")[0]
+		imdbListName = &name
 	case resourceTypeRating:
+		const ratingFieldCount = 6 // indices 0 (const), 1, 2 and 5 (title type) are the furthest read
+		total := len(csvData) - 1  // csvData includes the header line
+		if total < 0 {
+			total = 0
+		}
+		c.progress.Start("imdb ratings")
+		c.progress.SetTotal(total)
+		defer c.progress.Finish()
 		for i, record := range csvData {
 			if i > 0 {
-				rating, err := strconv.Atoi(record[1])
-				if err != nil {
-					log.Fatalf("error parsing imdb rating value: %v", err)
+				if len(record) < ratingFieldCount {
+					return nil, nil, &RatingParseError{Row: i, Err: fmt.Errorf("row has %d fields, expected at least %d", len(record), ratingFieldCount)}
+				}
+				rating, aerr := strconv.Atoi(record[1])
+				if aerr != nil {
+					return nil, nil, &RatingParseError{Row: i, Err: aerr}
 				}
-				ratingDate, err := time.Parse("2006-01-02", record[2])
-				if err != nil {
-					log.Fatalf("error parsing imdb rating date: %v", err)
+				ratingDate, terr := time.Parse("2006-01-02", record[2])
+				if terr != nil {
+					return nil, nil, &RatingParseError{Row: i, Err: terr}
 				}
 				imdbList = append(imdbList, entities.ImdbItem{
 					Id:         record[0],
@@ -316,12 +608,40 @@ func readResponse(res *http.Response, resType string) (imdbListName *string, imd
 					Rating:     &rating,
 					RatingDate: &ratingDate,
 				})
+				if last := &imdbList[len(imdbList)-1]; last.TitleType == "" && c.omdb != nil {
+					*last = c.enrichItem(ctx, *last)
+				}
+				c.progress.Increment()
 			}
 		}
 	default:
-		log.Fatalf("unknown imdb response type")
+		return nil, nil, fmt.Errorf("unknown imdb response type %q", resType)
 	}
-	return imdbListName, imdbList
+	return imdbListName, imdbList, nil
+}
+
+// enrichItem fills in fields missing from an imdb CSV row (most often
+// TitleType after one of IMDB's export redesigns) by querying OMDb. Fields
+// already populated by the CSV are kept as-is.
+func (c *ImdbClient) enrichItem(ctx context.Context, item entities.ImdbItem) entities.ImdbItem {
+	enriched, err := c.omdb.EnrichItem(ctx, item.Id)
+	if err != nil {
+		c.logger.Warn("failure enriching imdb item via omdb", zap.String("id", item.Id), zap.Error(err))
+		return item
+	}
+	if item.TitleType == "" {
+		item.TitleType = enriched.TitleType
+	}
+	if item.Title == "" {
+		item.Title = enriched.Title
+	}
+	if item.Year == "" {
+		item.Year = enriched.Year
+	}
+	if item.Runtime == 0 {
+		item.Runtime = enriched.Runtime
+	}
+	return item
 }
 
 func FormatTraktListName(imdbListName string) string {
@@ -330,9 +650,37 @@ func FormatTraktListName(imdbListName string) string {
 	return re.ReplaceAllString(formatted, "")
 }
 
-func DrainBody(body io.ReadCloser) {
-	err := body.Close()
-	if err != nil {
-		log.Fatalf("error closing response body: %v", err)
+// readBody reads body to completion, racing it against ctx's deadline via a
+// deadlineTimer so a hung imdb response can't block a caller forever even if
+// the transport itself doesn't notice ctx was cancelled. On the deadline
+// path it closes body itself to unblock the still-running read, and waits
+// for that goroutine to actually exit before returning - otherwise the
+// caller's own deferred Close on the same body would run concurrently with
+// the in-flight Read.
+func readBody(ctx context.Context, body io.ReadCloser) ([]byte, error) {
+	dt := newDeadlineTimer(ctx)
+	var (
+		data []byte
+		err  error
+	)
+	go func() {
+		data, err = io.ReadAll(body)
+		dt.finish()
+	}()
+	if werr := dt.wait(); werr != nil {
+		body.Close()
+		<-dt.done
+		return nil, werr
+	}
+	return data, err
+}
+
+// drainBody closes body, logging via logger on failure instead of returning
+// an error no caller using it in a defer could usefully act on. Shared by
+// ImdbClient and OmdbClient so there's a single place response bodies get
+// closed.
+func drainBody(logger *zap.Logger, body io.ReadCloser) {
+	if err := body.Close(); err != nil {
+		logger.Warn("failure closing response body", zap.Error(err))
 	}
 }
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cecobask/imdb-trakt-sync/pkg/entities"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+const (
+	omdbPathBase           = "https://www.omdbapi.com"
+	omdbDailyFreeTierLimit = 1000
+	cacheKeyOmdbItem       = "omdb.item.%s"
+)
+
+// OmdbClient enriches imdb items with metadata OMDb has but an imdb CSV
+// export sometimes omits or renames after a site redesign.
+type OmdbClient struct {
+	endpoint string
+	client   *http.Client
+	apiKey   string
+	limiter  *rate.Limiter
+	cache    Cache
+	cacheTTL time.Duration
+	logger   *zap.Logger
+}
+
+type OmdbConfig struct {
+	ApiKey   string
+	CacheDir string
+	CacheTTL time.Duration
+}
+
+func NewOmdbClient(config OmdbConfig, logger *zap.Logger) (*OmdbClient, error) {
+	if config.ApiKey == "" {
+		return nil, fmt.Errorf("omdb api key is required")
+	}
+	cache, cacheTTL, err := setupCache(config.CacheDir, config.CacheTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failure setting up omdb response cache: %w", err)
+	}
+	return &OmdbClient{
+		endpoint: omdbPathBase,
+		client:   &http.Client{},
+		apiKey:   config.ApiKey,
+		limiter:  rate.NewLimiter(rate.Every(24*time.Hour/omdbDailyFreeTierLimit), 1),
+		cache:    cache,
+		cacheTTL: cacheTTL,
+		logger:   logger,
+	}, nil
+}
+
+type omdbResponse struct {
+	Title    string `json:"Title"`
+	Year     string `json:"Year"`
+	Runtime  string `json:"Runtime"`
+	Type     string `json:"Type"`
+	ImdbID   string `json:"imdbID"`
+	Response string `json:"Response"`
+	Error    string `json:"Error"`
+}
+
+// EnrichItem looks up id (a "tt..." imdb id) against the OMDb API, so callers
+// can bootstrap an item that was added to trakt without ever appearing in an
+// imdb CSV export. ctx governs both the rate limiter wait - the limiter
+// spaces requests 86.4 seconds apart (1000 a day), so a wait can run for
+// well over a minute - and the http request itself, so a caller enriching
+// many items can't be stuck past its own deadline.
+func (c *OmdbClient) EnrichItem(ctx context.Context, id string) (entities.ImdbItem, error) {
+	cacheKey := fmt.Sprintf(cacheKeyOmdbItem, id)
+	if c.cache != nil {
+		if entry, ok := c.cache.Get(cacheKey); ok && !entry.expired() {
+			var cached omdbResponse
+			if err := json.Unmarshal(entry.Body, &cached); err == nil {
+				return omdbItemFromResponse(cached), nil
+			}
+		}
+	}
+	if err := c.limiter.Wait(ctx); err != nil {
+		return entities.ImdbItem{}, fmt.Errorf("failure waiting for omdb rate limiter: %w", err)
+	}
+	reqUrl := fmt.Sprintf("%s/?apikey=%s&i=%s", c.endpoint, url.QueryEscape(c.apiKey), url.QueryEscape(id))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqUrl, nil)
+	if err != nil {
+		return entities.ImdbItem{}, fmt.Errorf("failure creating omdb request for %s: %w", id, err)
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return entities.ImdbItem{}, fmt.Errorf("failure querying omdb for %s: %w", id, err)
+	}
+	defer drainBody(c.logger, res.Body)
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return entities.ImdbItem{}, fmt.Errorf("failure reading omdb response body for %s: %w", id, err)
+	}
+	var parsed omdbResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return entities.ImdbItem{}, fmt.Errorf("failure unmarshalling omdb response for %s: %w", id, err)
+	}
+	if parsed.Response == "False" {
+		return entities.ImdbItem{}, fmt.Errorf("omdb returned an error for %s: %s", id, parsed.Error)
+	}
+	if c.cache != nil {
+		if err := c.cache.Set(cacheKey, &cacheEntry{
+			Body:      body,
+			ExpiresAt: time.Now().Add(c.cacheTTL),
+		}); err != nil {
+			c.logger.Warn("failure caching omdb response", zap.String("id", id), zap.Error(err))
+		}
+	}
+	return omdbItemFromResponse(parsed), nil
+}
+
+func omdbItemFromResponse(r omdbResponse) entities.ImdbItem {
+	item := entities.ImdbItem{
+		Id:        r.ImdbID,
+		Title:     r.Title,
+		TitleType: r.Type,
+		Year:      r.Year,
+	}
+	if runtime, err := strconv.Atoi(strings.TrimSuffix(r.Runtime, " min")); err == nil {
+		item.Runtime = runtime
+	}
+	return item
+}
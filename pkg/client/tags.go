@@ -0,0 +1,61 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// TagStore persists the user-assigned tags for each imdb list (e.g.
+// "movies-only", "no-trakt", "mirror-only") across runs, so a schedule
+// doesn't need to redeclare them on every sync.
+type TagStore struct {
+	path string
+	tags map[string][]string
+}
+
+// NewTagStore loads the tag store at path, treating a missing file as an
+// empty store rather than an error.
+func NewTagStore(path string) (*TagStore, error) {
+	store := &TagStore{
+		path: path,
+		tags: make(map[string][]string),
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("failure reading tag store %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &store.tags); err != nil {
+		return nil, fmt.Errorf("failure parsing tag store %s: %w", path, err)
+	}
+	return store, nil
+}
+
+func (s *TagStore) Tags(listId string) []string {
+	return s.tags[listId]
+}
+
+func (s *TagStore) HasTag(listId, tag string) bool {
+	for _, t := range s.tags[listId] {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// SetTags replaces the tags assigned to listId and persists the store.
+func (s *TagStore) SetTags(listId string, tags []string) error {
+	s.tags[listId] = tags
+	data, err := json.MarshalIndent(s.tags, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failure marshalling tag store: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failure writing tag store %s: %w", s.path, err)
+	}
+	return nil
+}
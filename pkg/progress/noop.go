@@ -0,0 +1,14 @@
+package progress
+
+// noop is installed for library/worker use, where there's no terminal to
+// render a bar to and no one watching stdout anyway.
+type noop struct{}
+
+func NewNoop() Progress {
+	return noop{}
+}
+
+func (noop) Start(string) {}
+func (noop) SetTotal(int) {}
+func (noop) Increment()   {}
+func (noop) Finish()      {}
@@ -0,0 +1,15 @@
+package progress
+
+// Progress reports on a batch of work as it happens, so a long-running sync
+// can show what it's doing instead of going silent until it exits.
+type Progress interface {
+	// Start begins reporting progress for a new batch of work identified by
+	// label, e.g. an imdb list name.
+	Start(label string)
+	// SetTotal records how many items the current batch contains, once known.
+	SetTotal(total int)
+	// Increment reports that one more item in the current batch completed.
+	Increment()
+	// Finish closes out the current batch and prints a summary line.
+	Finish()
+}
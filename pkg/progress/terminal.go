@@ -0,0 +1,53 @@
+package progress
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cheggaaa/pb/v3"
+)
+
+// terminal renders a bar per batch to stdout, showing items/sec and an ETA,
+// and prints a one-line running summary each time a batch finishes.
+type terminal struct {
+	bar     *pb.ProgressBar
+	started time.Time
+	synced  int
+	batches int
+}
+
+func NewTerminal() Progress {
+	return &terminal{}
+}
+
+func (t *terminal) Start(label string) {
+	if t.started.IsZero() {
+		t.started = time.Now()
+	}
+	t.batches++
+	t.bar = pb.New(0)
+	t.bar.SetTemplateString(fmt.Sprintf(`%s {{counters . }} {{bar . }} {{percent . }} {{speed . "%%s items/s" }} {{rtime . "ETA %%s" }}`, label))
+	t.bar.SetWriter(os.Stdout)
+	t.bar.Start()
+}
+
+func (t *terminal) SetTotal(total int) {
+	if t.bar != nil {
+		t.bar.SetTotal(int64(total))
+	}
+}
+
+func (t *terminal) Increment() {
+	if t.bar != nil {
+		t.bar.Increment()
+	}
+	t.synced++
+}
+
+func (t *terminal) Finish() {
+	if t.bar != nil {
+		t.bar.Finish()
+	}
+	fmt.Printf("synced %d items across %d batches in %s\n", t.synced, t.batches, time.Since(t.started).Round(time.Second))
+}
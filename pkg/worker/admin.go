@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AdminServer exposes a small HTTP API for enqueuing jobs and inspecting
+// queue state, so a self-hosted worker can be driven without shelling into
+// its host.
+type AdminServer struct {
+	queue  Queue
+	logger *zap.Logger
+}
+
+func NewAdminServer(queue Queue, logger *zap.Logger) *AdminServer {
+	return &AdminServer{
+		queue:  queue,
+		logger: logger,
+	}
+}
+
+func (s *AdminServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jobs", s.handleJobs)
+	return mux
+}
+
+type enqueueRequest struct {
+	Kind       Kind   `json:"kind"`
+	ListId     string `json:"listId,omitempty"`
+	MaxRetries int    `json:"maxRetries,omitempty"`
+}
+
+func (s *AdminServer) handleJobs(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.listJobs(w, r)
+	case http.MethodPost:
+		s.enqueueJob(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *AdminServer) listJobs(w http.ResponseWriter, _ *http.Request) {
+	jobs, err := s.queue.List()
+	if err != nil {
+		s.writeError(w, fmt.Errorf("failure listing jobs: %w", err))
+		return
+	}
+	s.writeJSON(w, http.StatusOK, jobs)
+}
+
+func (s *AdminServer) enqueueJob(w http.ResponseWriter, r *http.Request) {
+	var req enqueueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, fmt.Errorf("failure decoding enqueue request: %w", err))
+		return
+	}
+	if req.MaxRetries == 0 {
+		req.MaxRetries = 5
+	}
+	job := &Job{
+		ID:         uuid.NewString(),
+		Kind:       req.Kind,
+		ListId:     req.ListId,
+		Status:     StatusPending,
+		MaxRetries: req.MaxRetries,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	if err := s.queue.Enqueue(job); err != nil {
+		s.writeError(w, fmt.Errorf("failure enqueuing job: %w", err))
+		return
+	}
+	s.writeJSON(w, http.StatusAccepted, job)
+}
+
+func (s *AdminServer) writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		s.logger.Error("failure writing admin response", zap.Error(err))
+	}
+}
+
+func (s *AdminServer) writeError(w http.ResponseWriter, err error) {
+	s.logger.Error("admin request failed", zap.Error(err))
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
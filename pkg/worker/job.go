@@ -0,0 +1,37 @@
+package worker
+
+import "time"
+
+// Kind identifies the unit of work a Job represents.
+type Kind string
+
+const (
+	KindFullSync      Kind = "full-sync"
+	KindRatingsOnly   Kind = "ratings-only"
+	KindSingleList    Kind = "single-list"
+	KindWatchlistOnly Kind = "watchlist-only"
+)
+
+// Status tracks where a Job is in its lifecycle.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// Job is a single scheduled sync operation, durably queued so it survives a
+// worker restart between the time it's enqueued and the time it runs.
+type Job struct {
+	ID         string    `json:"id"`
+	Kind       Kind      `json:"kind"`
+	ListId     string    `json:"listId,omitempty"` // only set when Kind == KindSingleList
+	Status     Status    `json:"status"`
+	Attempts   int       `json:"attempts"`
+	MaxRetries int       `json:"maxRetries"`
+	LastError  string    `json:"lastError,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,132 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const defaultRetryBaseDelay = 2 * time.Second
+
+// TerminalError wraps an error that must not be retried regardless of how
+// many attempts remain, e.g. an expired imdb session that requires the user
+// to refresh their cookies.
+type TerminalError struct {
+	Err error
+}
+
+func (e *TerminalError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TerminalError) Unwrap() error {
+	return e.Err
+}
+
+// Runner executes a single job against the sync clients, honouring ctx so a
+// hung imdb request doesn't tie up a worker goroutine forever. It's supplied
+// by the calling application so this package stays free of imdb/trakt
+// specifics.
+type Runner func(ctx context.Context, job *Job) error
+
+// Pool pulls pending jobs off a Queue and runs them with a fixed number of
+// concurrent workers, retrying transient failures with linear backoff.
+type Pool struct {
+	queue        Queue
+	runner       Runner
+	logger       *zap.Logger
+	concurrency  int
+	pollInterval time.Duration
+	jobTimeout   time.Duration
+	stop         chan struct{}
+	wg           sync.WaitGroup
+}
+
+func NewPool(queue Queue, runner Runner, concurrency int, logger *zap.Logger) *Pool {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Pool{
+		queue:        queue,
+		runner:       runner,
+		logger:       logger,
+		concurrency:  concurrency,
+		pollInterval: time.Second,
+		jobTimeout:   5 * time.Minute,
+		stop:         make(chan struct{}),
+	}
+}
+
+// Start launches the configured number of worker goroutines. It returns
+// immediately; call Stop to drain and shut them down.
+func (p *Pool) Start() {
+	for i := 0; i < p.concurrency; i++ {
+		p.wg.Add(1)
+		go p.loop()
+	}
+}
+
+func (p *Pool) Stop() {
+	close(p.stop)
+	p.wg.Wait()
+}
+
+func (p *Pool) loop() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			job, err := p.queue.Dequeue()
+			if err != nil {
+				p.logger.Error("failure dequeuing job", zap.Error(err))
+				continue
+			}
+			if job == nil {
+				continue
+			}
+			p.run(job)
+		}
+	}
+}
+
+func (p *Pool) run(job *Job) {
+	job.Attempts++
+	job.UpdatedAt = time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), p.jobTimeout)
+	defer cancel()
+	err := p.runner(ctx, job)
+	switch {
+	case err == nil:
+		job.Status = StatusSucceeded
+		job.LastError = ""
+	case isTerminal(err):
+		job.Status = StatusFailed
+		job.LastError = err.Error()
+		p.logger.Error("job failed with a terminal error, not retrying", zap.String("jobId", job.ID), zap.Error(err))
+	case job.Attempts > job.MaxRetries:
+		job.Status = StatusFailed
+		job.LastError = err.Error()
+		p.logger.Error("job exhausted its retries", zap.String("jobId", job.ID), zap.Int("attempts", job.Attempts), zap.Error(err))
+	default:
+		job.Status = StatusPending
+		job.LastError = err.Error()
+		backoff := defaultRetryBaseDelay * time.Duration(job.Attempts)
+		p.logger.Warn("job failed, will retry", zap.String("jobId", job.ID), zap.Duration("backoff", backoff), zap.Error(err))
+		time.Sleep(backoff)
+	}
+	if uerr := p.queue.Update(job); uerr != nil {
+		p.logger.Error("failure persisting job state", zap.String("jobId", job.ID), zap.Error(uerr))
+	}
+}
+
+func isTerminal(err error) bool {
+	var terminal *TerminalError
+	return errors.As(err, &terminal)
+}
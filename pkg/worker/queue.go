@@ -0,0 +1,167 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const jobsBucket = "jobs"
+
+// Queue is a durable FIFO-ish store of Jobs. Implementations must survive a
+// process restart with any pending jobs intact, and must requeue jobs that
+// were left running when the previous process stopped.
+type Queue interface {
+	Enqueue(job *Job) error
+	// Dequeue claims the oldest pending job, marking it as running, or
+	// returns a nil job if none are pending.
+	Dequeue() (*Job, error)
+	Update(job *Job) error
+	List() ([]*Job, error)
+	Close() error
+}
+
+type boltQueue struct {
+	db *bolt.DB
+}
+
+// NewBoltQueue opens (creating if necessary) a BoltDB-backed Queue at path,
+// requeuing any job left StatusRunning from a previous process as pending.
+func NewBoltQueue(path string) (Queue, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failure opening bolt db at %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(jobsBucket))
+		if err != nil {
+			return err
+		}
+		// a job left as StatusRunning can only mean the previous process
+		// died (or was killed) mid-run or during its retry backoff sleep -
+		// nothing ever transitions a job out of StatusRunning except Update
+		// after it finishes, so without this sweep it would be orphaned
+		// forever instead of picked up again. Keys are collected first and
+		// put back after ForEach returns, rather than mutating the bucket
+		// while it's being iterated.
+		var stale [][]byte
+		if err := bucket.ForEach(func(k, v []byte) error {
+			var job Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return fmt.Errorf("failure unmarshalling job %s: %w", k, err)
+			}
+			if job.Status == StatusRunning {
+				stale = append(stale, append([]byte(nil), k...))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range stale {
+			v := bucket.Get(k)
+			var job Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return fmt.Errorf("failure unmarshalling job %s: %w", k, err)
+			}
+			job.Status = StatusPending
+			data, err := json.Marshal(&job)
+			if err != nil {
+				return fmt.Errorf("failure marshalling job %s: %w", job.ID, err)
+			}
+			if err := bucket.Put(k, data); err != nil {
+				return fmt.Errorf("failure requeuing stale running job %s: %w", job.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failure creating jobs bucket: %w", err)
+	}
+	return &boltQueue{db: db}, nil
+}
+
+func (q *boltQueue) Enqueue(job *Job) error {
+	if err := q.db.Update(func(tx *bolt.Tx) error {
+		data, err := json.Marshal(job)
+		if err != nil {
+			return fmt.Errorf("failure marshalling job %s: %w", job.ID, err)
+		}
+		return tx.Bucket([]byte(jobsBucket)).Put([]byte(job.ID), data)
+	}); err != nil {
+		return fmt.Errorf("failure enqueuing job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+func (q *boltQueue) Dequeue() (*Job, error) {
+	var (
+		next    *Job
+		nextKey []byte
+	)
+	err := q.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(jobsBucket))
+		// jobs are keyed by random UUID, so bucket iteration order carries
+		// no ordering guarantee - pick the oldest pending job by CreatedAt
+		// instead of just taking the first pending one ForEach visits.
+		if err := bucket.ForEach(func(k, v []byte) error {
+			var job Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return fmt.Errorf("failure unmarshalling job %s: %w", k, err)
+			}
+			if job.Status != StatusPending {
+				return nil
+			}
+			if next == nil || job.CreatedAt.Before(next.CreatedAt) {
+				jobCopy := job
+				next = &jobCopy
+				nextKey = append(nextKey[:0], k...)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		if next == nil {
+			return nil
+		}
+		next.Status = StatusRunning
+		data, err := json.Marshal(next)
+		if err != nil {
+			return fmt.Errorf("failure marshalling job %s: %w", next.ID, err)
+		}
+		return bucket.Put(nextKey, data)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failure dequeuing job: %w", err)
+	}
+	return next, nil
+}
+
+func (q *boltQueue) Update(job *Job) error {
+	return q.Enqueue(job)
+}
+
+func (q *boltQueue) List() ([]*Job, error) {
+	var jobs []*Job
+	err := q.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(jobsBucket)).ForEach(func(k, v []byte) error {
+			var job Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return fmt.Errorf("failure unmarshalling job %s: %w", k, err)
+			}
+			jobs = append(jobs, &job)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failure listing jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+func (q *boltQueue) Close() error {
+	if err := q.db.Close(); err != nil {
+		return fmt.Errorf("failure closing bolt db: %w", err)
+	}
+	return nil
+}